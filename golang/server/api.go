@@ -4,6 +4,7 @@ package main
 // Contains logic for interacting with the external Congress.gov API.
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,55 +17,106 @@ import (
 
 const baseURL = "https://api.congress.gov/v3"
 
-var memberCache = NewCache()
+var memberCache = NewLayeredCache(NewCache[[]Member](), NewDiskCache[[]Member](cacheDir()))
 
-// getMembers fetches member data for a given state from the Congress.gov API.
-// It handles API key reading, request building, retries, and response parsing.
-func getMembers(state string) ([]Member, error) {
-	// Check cache first
-	if cachedMembers, found := memberCache.Get(state); found {
-		return cachedMembers, nil
+// congressProvider implements MemberProvider against the Congress.gov API,
+// covering federal House and Senate members.
+type congressProvider struct{}
+
+func (congressProvider) MembersByState(ctx context.Context, state string) ([]Member, error) {
+	members, err := getMembers(ctx, state)
+	if err != nil {
+		return nil, err
 	}
+	for i := range members {
+		members[i].Chamber = "federal"
+	}
+	return members, nil
+}
 
-	// Create a map of state codes (e.g., "AR") to full state names (e.g., "Arkansas")
-	// to filter the API results, which use the full name.
-	stateCodeToNameMap := make(map[string]string)
-	for _, s := range getStateList() {
-		stateCodeToNameMap[s.Code] = s.Name
+func (congressProvider) MembersByLocation(ctx context.Context, lat, lon float64) ([]Member, error) {
+	stateCode, district, err := reverseGeocodeToDistrict(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving congressional district: %w", err)
 	}
-	stateFullName, ok := stateCodeToNameMap[state]
-	if !ok {
-		// Return an empty slice for an invalid state code; the client can handle it.
-		return []Member{}, nil
+
+	members, err := getMembersByDistrict(ctx, stateCode, district)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		members[i].Chamber = "federal"
 	}
+	return members, nil
+}
+
+// getMembers fetches member data for a given state from the Congress.gov API.
+// It handles API key reading, request building, retries, and response parsing.
+func getMembers(ctx context.Context, state string) ([]Member, error) {
+	return memberCache.GetOrLoad(state, 1*time.Hour, func() ([]Member, error) {
+		// Create a map of state codes (e.g., "AR") to full state names (e.g., "Arkansas")
+		// to filter the API results, which use the full name.
+		stateCodeToNameMap := make(map[string]string)
+		for _, s := range getStateList() {
+			stateCodeToNameMap[s.Code] = s.Name
+		}
+		stateFullName, ok := stateCodeToNameMap[state]
+		if !ok {
+			// Return an empty slice for an invalid state code; the client can handle it.
+			return []Member{}, nil
+		}
+
+		path := fmt.Sprintf("/member/%s", state)
+		rawJSON, err := fetchJSON(ctx, path, map[string]string{
+			"format": "json",
+			"limit":  "75", // Fetch all members of Congress to filter locally
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching all members: %w", err)
+		}
+
+		allApiMembers, err := decodeData(rawJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding all members response: %w", err)
+		}
 
-	path := fmt.Sprintf("/member/%s", state)
-	rawJSON, err := fetchJSON(path, map[string]string{
+		var members []Member
+		// Iterate through all members and filter for the requested state.
+		for _, apiM := range allApiMembers {
+			if apiM.State == stateFullName {
+				if member, ok := apiMemberToMember(apiM); ok {
+					members = append(members, member)
+				}
+			}
+		}
+
+		return members, nil
+	})
+}
+
+// getMembersByDistrict fetches the current members of Congress representing a
+// specific state and congressional district number (used by location lookups,
+// after a (lat, lon) point has been resolved to a district).
+func getMembersByDistrict(ctx context.Context, stateCode string, district int) ([]Member, error) {
+	path := fmt.Sprintf("/member/%s/%d", stateCode, district)
+	rawJSON, err := fetchJSON(ctx, path, map[string]string{
 		"format": "json",
-		"limit":  "75", // Fetch all members of Congress to filter locally
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed fetching all members: %w", err)
+		return nil, fmt.Errorf("failed fetching district members: %w", err)
 	}
 
-	allApiMembers, err := decodeData(rawJSON)
+	apiMembers, err := decodeData(rawJSON)
 	if err != nil {
-		return nil, fmt.Errorf("failed decoding all members response: %w", err)
+		return nil, fmt.Errorf("failed decoding district members response: %w", err)
 	}
 
 	var members []Member
-	// Iterate through all members and filter for the requested state.
-	for _, apiM := range allApiMembers {
-		if apiM.State == stateFullName {
-			if member, ok := apiMemberToMember(apiM); ok {
-				members = append(members, member)
-			}
+	for _, apiM := range apiMembers {
+		if member, ok := apiMemberToMember(apiM); ok {
+			members = append(members, member)
 		}
 	}
-
-	// Cache the filtered result for this specific state
-	memberCache.Set(state, members, 1*time.Hour)
-
 	return members, nil
 }
 
@@ -100,7 +152,10 @@ func decodeData(raw []byte) ([]ApiMember, error) {
 
 // fetchJSON performs a GET request to a specified path of the Congress.gov API.
 // It automatically adds the API key and includes a simple retry mechanism.
-func fetchJSON(path string, params map[string]string) ([]byte, error) {
+// The request is bound to ctx: if the caller hangs up or the deadline (the
+// earlier of ctx's own deadline and API_TIMEOUT) passes, fetchJSON returns
+// promptly instead of waiting out the full retry loop.
+func fetchJSON(ctx context.Context, path string, params map[string]string) ([]byte, error) {
 	apiKey, err := readAPIKey()
 	if err != nil {
 		return nil, err
@@ -118,19 +173,32 @@ func fetchJSON(path string, params map[string]string) ([]byte, error) {
 	}
 	u.RawQuery = q.Encode()
 
-	client := &http.Client{Timeout: 15 * time.Second}
+	ctx, cancel := withAPIDeadline(ctx)
+	defer cancel()
+
+	client := &http.Client{}
 
 	var resp *http.Response
 	const maxRetries = 3
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		resp, err = client.Get(u.String())
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if reqErr != nil {
+			return nil, fmt.Errorf("invalid api request: %w", reqErr)
+		}
+
+		resp, err = client.Do(req)
 		if err == nil {
 			break // Success
 		}
 		if attempt == maxRetries {
 			return nil, fmt.Errorf("http request failed after %d attempts: %w", maxRetries, err)
 		}
-		time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+		}
 	}
 	defer resp.Body.Close()
 
@@ -1,49 +1,185 @@
 package main
 
 import (
+	"container/list"
 	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
-// CacheItem represents a single item in the cache
-type CacheItem struct {
-	Value     []Member
+// defaultSweepInterval is how often a Cache walks its items looking for
+// expired entries to reclaim.
+const defaultSweepInterval = 5 * time.Minute
+
+// CacheItem represents a single item in the cache.
+type CacheItem[V any] struct {
+	Value     V
 	ExpiresAt time.Time
 }
 
-// Cache is a simple in-memory cache with TTL
-type Cache struct {
-	items map[string]CacheItem
-	mu    sync.RWMutex
+// Cache is a generic in-memory cache with TTL. Concurrent misses for the same
+// key are collapsed into a single loader call via GetOrLoad, a background
+// goroutine sweeps out expired entries so the map doesn't grow unbounded, and
+// an optional maxSize turns it into an LRU cache.
+type Cache[V any] struct {
+	mu    sync.Mutex
+	items map[string]CacheItem[V]
+	group singleflight.Group
+
+	stopSweep chan struct{}
+	closeOnce sync.Once
+
+	maxSize  int
+	lru      *list.List
+	lruElems map[string]*list.Element
 }
 
-// NewCache creates a new Cache instance
-func NewCache() *Cache {
-	return &Cache{
-		items: make(map[string]CacheItem),
+// NewCache creates an unbounded Cache instance and starts its sweeper.
+func NewCache[V any]() *Cache[V] {
+	return NewBoundedCache[V](0)
+}
+
+// NewBoundedCache creates a Cache instance that evicts its least-recently-used
+// entry once it holds more than maxSize items. maxSize <= 0 means unbounded.
+func NewBoundedCache[V any](maxSize int) *Cache[V] {
+	c := &Cache[V]{
+		items:     make(map[string]CacheItem[V]),
+		stopSweep: make(chan struct{}),
+		maxSize:   maxSize,
+	}
+	if maxSize > 0 {
+		c.lru = list.New()
+		c.lruElems = make(map[string]*list.Element)
 	}
+	go c.sweepLoop(defaultSweepInterval)
+	return c
 }
 
 // Get retrieves an item from the cache.
 // It returns the item and a boolean indicating if the item was found and is not expired.
-func (c *Cache) Get(key string) ([]Member, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	item, found := c.items[key]
 	if !found || time.Now().After(item.ExpiresAt) {
-		return nil, false
+		var zero V
+		return zero, false
 	}
+	c.touch(key)
 	return item.Value, true
 }
 
 // Set adds an item to the cache with a specified TTL.
-func (c *Cache) Set(key string, value []Member, ttl time.Duration) {
+func (c *Cache[V]) Set(key string, value V, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.items[key] = CacheItem{
+	c.items[key] = CacheItem[V]{
 		Value:     value,
 		ExpiresAt: time.Now().Add(ttl),
 	}
+	c.touch(key)
+	c.evictIfNeeded()
+}
+
+// GetOrLoad returns the cached value for key if present and unexpired;
+// otherwise it calls loader to compute the value and caches it with ttl.
+// Concurrent calls for the same key that miss the cache share a single
+// loader call instead of each launching their own upstream request.
+func (c *Cache[V]) GetOrLoad(key string, ttl time.Duration, loader func() (V, error)) (V, error) {
+	if value, found := c.Get(key); found {
+		return value, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
+		// Another goroutine may have populated the cache while we waited to
+		// become the leader for this key.
+		if value, found := c.Get(key); found {
+			return value, nil
+		}
+		value, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		c.Set(key, value, ttl)
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return result.(V), nil
+}
+
+// Close stops the background sweeper. Safe to call more than once.
+func (c *Cache[V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stopSweep)
+	})
+}
+
+// touch records key as most-recently-used. No-op for unbounded caches.
+// Callers must hold c.mu.
+func (c *Cache[V]) touch(key string) {
+	if c.lru == nil {
+		return
+	}
+	if elem, ok := c.lruElems[key]; ok {
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.lruElems[key] = c.lru.PushFront(key)
+}
+
+// evictIfNeeded removes least-recently-used entries until the cache is back
+// within maxSize. No-op for unbounded caches. Callers must hold c.mu.
+func (c *Cache[V]) evictIfNeeded() {
+	if c.lru == nil {
+		return
+	}
+	for len(c.items) > c.maxSize {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			return
+		}
+		key := oldest.Value.(string)
+		c.lru.Remove(oldest)
+		delete(c.lruElems, key)
+		delete(c.items, key)
+	}
+}
+
+// sweepLoop periodically deletes expired entries until Close is called.
+func (c *Cache[V]) sweepLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-c.stopSweep:
+			return
+		}
+	}
+}
+
+// sweep deletes expired entries from the cache.
+func (c *Cache[V]) sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, item := range c.items {
+		if now.After(item.ExpiresAt) {
+			delete(c.items, key)
+			if c.lru != nil {
+				if elem, ok := c.lruElems[key]; ok {
+					c.lru.Remove(elem)
+					delete(c.lruElems, key)
+				}
+			}
+		}
+	}
 }
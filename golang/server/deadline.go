@@ -0,0 +1,33 @@
+package main
+
+// deadline.go
+// Shared helper for giving upstream API calls a bounded deadline that
+// respects both the caller's context and a configurable ceiling, so a
+// request that hangs doesn't hold resources forever even if its context
+// has no deadline of its own.
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+const defaultAPITimeout = 15 * time.Second
+
+// apiTimeout returns the configurable max per-call deadline from the
+// API_TIMEOUT env var (a Go duration string, e.g. "20s"), defaulting to 15s.
+func apiTimeout() time.Duration {
+	if v := os.Getenv("API_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultAPITimeout
+}
+
+// withAPIDeadline returns a context whose deadline is the earlier of ctx's
+// existing deadline (if any) and apiTimeout() from now — context.WithDeadline
+// already takes the earlier of the two, so this just supplies our ceiling.
+func withAPIDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, time.Now().Add(apiTimeout()))
+}
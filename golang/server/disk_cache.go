@@ -0,0 +1,219 @@
+package main
+
+// disk_cache.go
+// Adds a disk-backed cache tier and a LayeredCache that sits in front of it,
+// so a server restart doesn't cold-start every in-memory cache and immediately
+// hammer upstream APIs (Congress.gov's key is rate-limited to 1,000 req/hour).
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// diskCacheStripes is the number of mutexes DiskCache stripes its per-key
+// locking across, so concurrent writes to different keys don't block each
+// other while same-key writes still can't corrupt each other's files.
+const diskCacheStripes = 256
+
+// keyedMutex hands out one of a fixed set of mutexes based on a key's hash.
+type keyedMutex struct {
+	locks [diskCacheStripes]sync.Mutex
+}
+
+func (k *keyedMutex) forKey(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &k.locks[h.Sum32()%diskCacheStripes]
+}
+
+// diskCacheMeta is the sidecar ".meta" file recording when a cache entry expires.
+type diskCacheMeta struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// DiskCache persists cache entries as gzipped JSON files under dir, with a
+// sidecar ".meta" file per entry recording its expiry.
+type DiskCache[V any] struct {
+	dir   string
+	locks keyedMutex
+}
+
+// NewDiskCache creates a DiskCache rooted at dir. dir is created on first write.
+func NewDiskCache[V any](dir string) *DiskCache[V] {
+	return &DiskCache[V]{dir: dir}
+}
+
+// Get reads a cache entry from disk, returning (zero value, false) if it's
+// missing, expired, or unreadable.
+func (d *DiskCache[V]) Get(key string) (V, bool) {
+	value, _, found := d.getWithExpiry(key)
+	return value, found
+}
+
+// getWithExpiry is like Get but also returns the entry's expiry, so callers
+// promoting an entry back into memory can reuse the remaining TTL.
+func (d *DiskCache[V]) getWithExpiry(key string) (V, time.Time, bool) {
+	var zero V
+
+	mu := d.locks.forKey(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	metaPath, dataPath := d.paths(key)
+
+	metaBytes, err := os.ReadFile(metaPath)
+	if err != nil {
+		return zero, time.Time{}, false
+	}
+	var meta diskCacheMeta
+	if err := json.Unmarshal(metaBytes, &meta); err != nil || time.Now().After(meta.ExpiresAt) {
+		return zero, time.Time{}, false
+	}
+
+	f, err := os.Open(dataPath)
+	if err != nil {
+		return zero, time.Time{}, false
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return zero, time.Time{}, false
+	}
+	defer gz.Close()
+
+	var value V
+	if err := json.NewDecoder(gz).Decode(&value); err != nil {
+		return zero, time.Time{}, false
+	}
+	return value, meta.ExpiresAt, true
+}
+
+// Set writes a cache entry (gzipped JSON data file + a ".meta" sidecar with
+// the expiry) to disk, overwriting any existing entry for key.
+func (d *DiskCache[V]) Set(key string, value V, ttl time.Duration) error {
+	mu := d.locks.forKey(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return fmt.Errorf("failed creating cache dir %q: %w", d.dir, err)
+	}
+
+	metaPath, dataPath := d.paths(key)
+
+	f, err := os.Create(dataPath)
+	if err != nil {
+		return fmt.Errorf("failed creating cache file: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	if err := json.NewEncoder(gz).Encode(value); err != nil {
+		gz.Close()
+		return fmt.Errorf("failed writing cache file: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed closing cache file: %w", err)
+	}
+
+	metaBytes, err := json.Marshal(diskCacheMeta{ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return fmt.Errorf("failed marshaling cache meta: %w", err)
+	}
+	if err := os.WriteFile(metaPath, metaBytes, 0o644); err != nil {
+		return fmt.Errorf("failed writing cache meta: %w", err)
+	}
+	return nil
+}
+
+// paths maps a cache key to its data and meta file paths. Keys are hashed
+// with fnv since they may contain characters ("|", etc.) that aren't safe
+// filenames.
+func (d *DiskCache[V]) paths(key string) (metaPath, dataPath string) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	name := strconv.FormatUint(h.Sum64(), 16)
+	return filepath.Join(d.dir, name+".meta"), filepath.Join(d.dir, name+".json.gz")
+}
+
+// cacheDir returns the configured on-disk cache directory, defaulting to
+// "./cache" if CACHE_DIR isn't set.
+func cacheDir() string {
+	if dir := os.Getenv("CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return "./cache"
+}
+
+// LayeredCache sits in front of a DiskCache with a fast in-memory Cache,
+// so cache hits usually never touch disk but a process restart still has
+// warm data to promote back into memory instead of refetching from upstream.
+type LayeredCache[V any] struct {
+	mem   *Cache[V]
+	disk  *DiskCache[V]
+	group singleflight.Group
+}
+
+// NewLayeredCache creates a LayeredCache backed by mem and disk.
+func NewLayeredCache[V any](mem *Cache[V], disk *DiskCache[V]) *LayeredCache[V] {
+	return &LayeredCache[V]{mem: mem, disk: disk}
+}
+
+// Get checks memory first, then disk, promoting a disk hit back into memory.
+func (l *LayeredCache[V]) Get(key string) (V, bool) {
+	if value, found := l.mem.Get(key); found {
+		return value, true
+	}
+	if value, expiresAt, found := l.disk.getWithExpiry(key); found {
+		l.mem.Set(key, value, time.Until(expiresAt))
+		return value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set writes through to both the memory and disk tiers.
+func (l *LayeredCache[V]) Set(key string, value V, ttl time.Duration) {
+	l.mem.Set(key, value, ttl)
+	if err := l.disk.Set(key, value, ttl); err != nil {
+		log.Printf("disk cache write failed for key %q: %v", key, err)
+	}
+}
+
+// GetOrLoad returns the cached value for key (checking memory, then disk)
+// if present and unexpired; otherwise it calls loader, collapsing concurrent
+// misses for the same key into a single call, and writes the result through
+// to both tiers.
+func (l *LayeredCache[V]) GetOrLoad(key string, ttl time.Duration, loader func() (V, error)) (V, error) {
+	if value, found := l.Get(key); found {
+		return value, nil
+	}
+
+	result, err, _ := l.group.Do(key, func() (interface{}, error) {
+		if value, found := l.Get(key); found {
+			return value, nil
+		}
+		value, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		l.Set(key, value, ttl)
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return result.(V), nil
+}
@@ -0,0 +1,116 @@
+package main
+
+// geocode.go
+// Resolves a latitude/longitude into a congressional district via Geocodio.
+// This is the "point -> congressional district" step that getMembersByDistrict
+// needs before it can query Congress.gov, which only knows about state codes
+// and district numbers.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const geocodioBaseURL = "https://api.geocod.io/v1.7"
+
+// readGeocodioAPIKey retrieves the Geocodio API key from environment variables.
+func readGeocodioAPIKey() (string, error) {
+	key := os.Getenv("GEOCODIO_API_KEY")
+	if key == "" {
+		return "", errors.New("GEOCODIO_API_KEY environment variable is not set; get a key at https://www.geocod.io")
+	}
+	return key, nil
+}
+
+// geocodioReverseResponse is structured to match Geocodio's /reverse response,
+// trimmed down to the congressional district fields we need.
+type geocodioReverseResponse struct {
+	Results []struct {
+		AddressComponents struct {
+			State string `json:"state"`
+		} `json:"address_components"`
+		Fields struct {
+			CongressionalDistricts []struct {
+				DistrictNumber int `json:"district_number"`
+			} `json:"congressional_districts"`
+		} `json:"fields"`
+	} `json:"results"`
+}
+
+// reverseGeocodeToDistrict resolves a (lat, lon) point to its two-letter state
+// code and congressional district number via Geocodio's reverse geocoder.
+func reverseGeocodeToDistrict(ctx context.Context, lat, lon float64) (stateCode string, district int, err error) {
+	apiKey, err := readGeocodioAPIKey()
+	if err != nil {
+		return "", 0, err
+	}
+
+	u, err := url.Parse(geocodioBaseURL + "/reverse")
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid geocodio path: %w", err)
+	}
+	q := u.Query()
+	q.Set("q", fmt.Sprintf("%f,%f", lat, lon))
+	q.Set("fields", "cd")
+	q.Set("api_key", apiKey)
+	u.RawQuery = q.Encode()
+
+	ctx, cancel := withAPIDeadline(ctx)
+	defer cancel()
+
+	client := &http.Client{}
+
+	var resp *http.Response
+	const maxRetries = 3
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if reqErr != nil {
+			return "", 0, fmt.Errorf("invalid geocodio request: %w", reqErr)
+		}
+
+		resp, err = client.Do(req)
+		if err == nil {
+			break // Success
+		}
+		if attempt == maxRetries {
+			return "", 0, fmt.Errorf("geocodio request failed after %d attempts: %w", maxRetries, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", 0, ctx.Err()
+		case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return "", 0, fmt.Errorf("geocodio returned non-200 status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed reading geocodio response body: %w", err)
+	}
+
+	var parsed geocodioReverseResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", 0, fmt.Errorf("failed decoding geocodio response: %w", err)
+	}
+	if len(parsed.Results) == 0 || len(parsed.Results[0].Fields.CongressionalDistricts) == 0 {
+		return "", 0, errors.New("geocodio returned no congressional district for this point")
+	}
+
+	stateCode = strings.ToUpper(parsed.Results[0].AddressComponents.State)
+	district = parsed.Results[0].Fields.CongressionalDistricts[0].DistrictNumber
+	return stateCode, district, nil
+}
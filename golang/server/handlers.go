@@ -4,12 +4,29 @@ package main
 // Contains HTTP handlers for the API endpoints.
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+var (
+	errMissingLatLon = errors.New("missing required 'lat' and 'lon' query parameters (e.g. ?lat=40.7&lon=-74.0)")
+	errInvalidLat    = errors.New("'lat' must be a number between -90 and 90")
+	errInvalidLon    = errors.New("'lon' must be a number between -180 and 180")
+)
+
+// maxMemberLegislationLimit bounds the 'limit' query param on
+// /members/{bioguideId}/legislation. Without a cap, a large limit drives
+// getMemberLegislationPages through that many pages of the Congress.gov API
+// (250 items per page) inside a single inbound request.
+const maxMemberLegislationLimit = 500
+
 // statesHandler handles requests for GET /states.
 // It returns a static list of US states.
 func statesHandler(w http.ResponseWriter, r *http.Request) {
@@ -28,7 +45,8 @@ func statesHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // representativesHandler handles requests for GET /representatives?state=XX.
-// It fetches data from either a mock source or a live API based on the MODE env var.
+// It dispatches to the MemberProvider(s) selected by the MODE env var
+// ("mock", "congress", "openstates", or "all").
 func representativesHandler(w http.ResponseWriter, r *http.Request) {
 	enableCORS(w)
 	if r.Method == http.MethodOptions {
@@ -46,16 +64,14 @@ func representativesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mode := strings.ToLower(os.Getenv("MODE"))
-	var reps []Member
-	var err error
-
-	if mode == "real" {
-		reps, err = getMembers(state)
-	} else {
-		reps, err = getMembersMock(state)
+	providers, err := providersForMode(os.Getenv("MODE"))
+	if err != nil {
+		log.Printf("error resolving providers for representatives request: %v", err)
+		http.Error(w, "invalid server configuration", http.StatusInternalServerError)
+		return
 	}
 
+	reps, err := membersByStateFromProviders(r.Context(), providers, state)
 	if err != nil {
 		log.Printf("error getting representatives for state %s: %v", state, err)
 		http.Error(w, "internal server error while fetching representatives", http.StatusInternalServerError)
@@ -64,3 +80,123 @@ func representativesHandler(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, reps)
 }
+
+// representativesByLocationHandler handles requests for
+// GET /representatives-by-location?lat=..&lon=.. .
+// It resolves both the federal and state legislators whose district contains
+// the given point, using the MemberProvider(s) selected by the MODE env var.
+func representativesByLocationHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lat, lon, err := parseLatLon(r.URL.Query().Get("lat"), r.URL.Query().Get("lon"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reps, err := membersByLocationFromMode(r.Context(), os.Getenv("MODE"), lat, lon)
+	if err != nil {
+		log.Printf("error getting representatives for location (%f, %f): %v", lat, lon, err)
+		http.Error(w, "internal server error while fetching representatives", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reps)
+}
+
+// parseLatLon parses and validates the 'lat' and 'lon' query parameters,
+// requiring lat in [-90, 90] and lon in [-180, 180] and rejecting NaN.
+func parseLatLon(latParam, lonParam string) (lat, lon float64, err error) {
+	if latParam == "" || lonParam == "" {
+		return 0, 0, errMissingLatLon
+	}
+
+	lat, err = strconv.ParseFloat(latParam, 64)
+	if err != nil || math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return 0, 0, errInvalidLat
+	}
+
+	lon, err = strconv.ParseFloat(lonParam, 64)
+	if err != nil || math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return 0, 0, errInvalidLon
+	}
+
+	return lat, lon, nil
+}
+
+// memberLegislationHandler handles requests for
+// GET /members/{bioguideId}/legislation?type=sponsored|cosponsored|both&limit=N.
+func memberLegislationHandler(w http.ResponseWriter, r *http.Request) {
+	enableCORS(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bioguideID, ok := parseMemberLegislationPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	legType := strings.ToLower(r.URL.Query().Get("type"))
+	if legType == "" {
+		legType = "sponsored"
+	}
+	if legType != "sponsored" && legType != "cosponsored" && legType != "both" {
+		http.Error(w, "'type' must be one of sponsored, cosponsored, both", http.StatusBadRequest)
+		return
+	}
+
+	limit := 20
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 || parsed > maxMemberLegislationLimit {
+			http.Error(w, fmt.Sprintf("'limit' must be a positive integer no greater than %d", maxMemberLegislationLimit), http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	bills, err := getLegislationForMember(r.Context(), bioguideID, legType, limit)
+	if err != nil {
+		log.Printf("error getting legislation for member %s: %v", bioguideID, err)
+		http.Error(w, "internal server error while fetching legislation", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, bills)
+}
+
+// bioguideIDPattern matches a Congress.gov bioguide ID, e.g. "A000360": one
+// letter followed by six digits.
+var bioguideIDPattern = regexp.MustCompile(`^[A-Za-z]\d{6}$`)
+
+// parseMemberLegislationPath extracts the bioguideId from a request path of
+// the form "/members/{bioguideId}/legislation". It rejects anything that
+// doesn't look like a real bioguide ID so a crafted path segment (e.g. a
+// percent-encoded '?') can't smuggle extra query parameters into the
+// upstream Congress.gov request built from it.
+func parseMemberLegislationPath(path string) (bioguideID string, ok bool) {
+	trimmed := strings.TrimPrefix(path, "/members/")
+	if trimmed == path {
+		return "", false
+	}
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 || parts[1] != "legislation" || !bioguideIDPattern.MatchString(parts[0]) {
+		return "", false
+	}
+	return parts[0], true
+}
@@ -0,0 +1,170 @@
+package main
+
+// legislation.go
+// Contains logic for fetching a member's sponsored/cosponsored legislation
+// from the Congress.gov API.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var legislationCache = NewCache[[]Bill]()
+
+const legislationCacheTTL = 6 * time.Hour
+
+// getLegislationForMember returns a member's legislation for the requested
+// type ("sponsored", "cosponsored", or "both"), caching the merged result
+// under "bioguideID|type".
+func getLegislationForMember(ctx context.Context, bioguideID, legType string, limit int) ([]Bill, error) {
+	cacheKey := bioguideID + "|" + legType
+	return legislationCache.GetOrLoad(cacheKey, legislationCacheTTL, func() ([]Bill, error) {
+		switch legType {
+		case "sponsored":
+			return getSponsoredLegislation(ctx, bioguideID, limit)
+		case "cosponsored":
+			return getCosponsoredLegislation(ctx, bioguideID, limit)
+		case "both":
+			// Split the limit across both fetches so the combined result still
+			// honors the caller's limit=N contract instead of fetching up to
+			// limit bills from each side.
+			half := limit / 2
+			sponsored, err := getSponsoredLegislation(ctx, bioguideID, half)
+			if err != nil {
+				return nil, err
+			}
+			cosponsored, err := getCosponsoredLegislation(ctx, bioguideID, limit-half)
+			if err != nil {
+				return nil, err
+			}
+			return dedupeBills(append(sponsored, cosponsored...)), nil
+		default:
+			return nil, fmt.Errorf("unknown legislation type %q", legType)
+		}
+	})
+}
+
+// getSponsoredLegislation fetches up to limit bills sponsored by a member.
+func getSponsoredLegislation(ctx context.Context, bioguideID string, limit int) ([]Bill, error) {
+	return getMemberLegislationPages(ctx, bioguideID, "sponsored-legislation", limit)
+}
+
+// getCosponsoredLegislation fetches up to limit bills cosponsored by a member.
+func getCosponsoredLegislation(ctx context.Context, bioguideID string, limit int) ([]Bill, error) {
+	return getMemberLegislationPages(ctx, bioguideID, "cosponsored-legislation", limit)
+}
+
+// getMemberLegislationPages fetches bills of the given Congress.gov legislation
+// type ("sponsored-legislation" or "cosponsored-legislation") for a member,
+// following the API's "pagination.next" link until limit bills have been
+// collected or there are no more pages.
+func getMemberLegislationPages(ctx context.Context, bioguideID, legislationType string, limit int) ([]Bill, error) {
+	var bills []Bill
+	path := fmt.Sprintf("/member/%s/%s", bioguideID, legislationType)
+	params := map[string]string{
+		"format": "json",
+		"limit":  "250",
+	}
+
+	for path != "" && len(bills) < limit {
+		rawJSON, err := fetchJSON(ctx, path, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching %s: %w", legislationType, err)
+		}
+
+		apiBills, next, err := decodeBills(rawJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding %s response: %w", legislationType, err)
+		}
+
+		for _, apiB := range apiBills {
+			if len(bills) >= limit {
+				break
+			}
+			bills = append(bills, apiBillToBill(apiB))
+		}
+
+		path, params = nextPageRequest(next)
+	}
+
+	return bills, nil
+}
+
+// decodeBills extracts the list of bills from the nested JSON response
+// structure returned by the sponsored/cosponsored-legislation endpoints,
+// along with the "pagination.next" URL, if any.
+func decodeBills(raw []byte) (bills []ApiBill, next string, err error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &top); err != nil {
+		return nil, "", fmt.Errorf("invalid json structure: %w", err)
+	}
+
+	found := false
+	for key, value := range top {
+		if key == "request" || key == "pagination" {
+			continue
+		}
+		if err := json.Unmarshal(value, &bills); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal bills from key '%s': %w", key, err)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return nil, "", errors.New("no bill data array found in API response")
+	}
+
+	if rawPagination, ok := top["pagination"]; ok {
+		var pagination struct {
+			Next string `json:"next"`
+		}
+		if err := json.Unmarshal(rawPagination, &pagination); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal pagination: %w", err)
+		}
+		next = pagination.Next
+	}
+
+	return bills, next, nil
+}
+
+// nextPageRequest turns a Congress.gov pagination "next" URL (an absolute URL)
+// back into the path + params shape fetchJSON expects, so paging can reuse it.
+func nextPageRequest(next string) (path string, params map[string]string) {
+	if next == "" {
+		return "", nil
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", nil
+	}
+
+	params = make(map[string]string)
+	for k, v := range u.Query() {
+		if k == "api_key" || len(v) == 0 {
+			continue
+		}
+		params[k] = v[0]
+	}
+	return strings.TrimPrefix(u.Path, "/v3"), params
+}
+
+// dedupeBills removes duplicate bills (matched on Congress|Type|Number),
+// keeping the first occurrence.
+func dedupeBills(bills []Bill) []Bill {
+	seen := make(map[string]bool, len(bills))
+	deduped := make([]Bill, 0, len(bills))
+	for _, b := range bills {
+		key := fmt.Sprintf("%d|%s|%s", b.Congress, b.Type, b.Number)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, b)
+	}
+	return deduped
+}
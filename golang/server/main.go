@@ -8,7 +8,8 @@ package main
 // To run:
 //   go run .
 //
-// By default this runs on :8080. Use MODE=real and configure API keys
+// By default this runs on :8080 in mock mode. Set MODE=congress,
+// MODE=openstates, or MODE=all (and configure the relevant API keys)
 // if you want to fetch live data from an external API.
 
 import (
@@ -22,6 +23,8 @@ func main() {
 	// Register handlers for the API endpoints.
 	http.HandleFunc("/states", statesHandler)
 	http.HandleFunc("/representatives", representativesHandler)
+	http.HandleFunc("/representatives-by-location", representativesByLocationHandler)
+	http.HandleFunc("/members/", memberLegislationHandler)
 
 	// Configure the server with timeouts to enhance stability.
 	port := "8080"
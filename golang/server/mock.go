@@ -3,21 +3,39 @@ package main
 // mock.go
 // Provides mock data for development and testing.
 
+import (
+	"context"
+	"errors"
+)
+
+// mockProvider implements MemberProvider with the static demo dataset below.
+// It's the default provider (MODE unset or MODE=mock). The data is static and
+// local, so it ignores ctx entirely.
+type mockProvider struct{}
+
+func (mockProvider) MembersByState(ctx context.Context, state string) ([]Member, error) {
+	return getMembersMock(state)
+}
+
+func (mockProvider) MembersByLocation(ctx context.Context, lat, lon float64) ([]Member, error) {
+	return nil, errors.New("mock provider does not support location lookups")
+}
+
 // getMembersMock returns a small set of fake representatives for demo.
 // IMPORTANT: This is mock data and not real representatives.
 func getMembersMock(state string) ([]Member, error) {
 	// Minimal example mapping; expand as you like.
 	mockDB := map[string][]Member{
 		"NY": {
-			{ID: "rep-ny-1", FirstName: "Alex", LastName: "Johnson", Party: " (D)", District: "1"},
-			{ID: "rep-ny-2", FirstName: "Riley", LastName: "Martinez", Party: " (R)", District: "2"},
+			{ID: "rep-ny-1", FirstName: "Alex", LastName: "Johnson", Party: " (D)", District: "1", Chamber: "federal"},
+			{ID: "rep-ny-2", FirstName: "Riley", LastName: "Martinez", Party: " (R)", District: "2", Chamber: "federal"},
 		},
 		"CA": {
-			{ID: "rep-ca-12", FirstName: "Morgan", LastName: "Lee", Party: " (D)", District: "12"},
-			{ID: "rep-ca-14", FirstName: "Taylor", LastName: "Nguyen", Party: " (D)", District: "14"},
+			{ID: "rep-ca-12", FirstName: "Morgan", LastName: "Lee", Party: " (D)", District: "12", Chamber: "federal"},
+			{ID: "rep-ca-14", FirstName: "Taylor", LastName: "Nguyen", Party: " (D)", District: "14", Chamber: "federal"},
 		},
 		"TX": {
-			{ID: "rep-tx-7", FirstName: "Sam", LastName: "Williams", Party: " (R)", District: "7"},
+			{ID: "rep-tx-7", FirstName: "Sam", LastName: "Williams", Party: " (R)", District: "7", Chamber: "federal"},
 		},
 	}
 
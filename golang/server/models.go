@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // models.go
@@ -24,6 +25,57 @@ type Member struct {
 	District   string `json:"district"`
 	InitalYear int    `json:"initialYear"`
 	ImageURL   string `json:"imageUrl"`
+	// Chamber distinguishes federal members of Congress from state legislators
+	// now that /representatives can be backed by more than one provider.
+	// One of "federal" or "state-legislature".
+	Chamber string `json:"chamber"`
+}
+
+// Bill is the JSON shape returned to the Flutter app for a member's
+// sponsored/cosponsored legislation.
+type Bill struct {
+	Congress         int       `json:"congress"`
+	Type             string    `json:"type"`
+	Number           string    `json:"number"`
+	Title            string    `json:"title"`
+	IntroducedDate   time.Time `json:"introducedDate"`
+	LatestActionDate time.Time `json:"latestActionDate"`
+	LatestActionText string    `json:"latestActionText"`
+	URL              string    `json:"url"`
+}
+
+// ApiBill is structured to match a bill object from the Congress.gov
+// sponsored-legislation / cosponsored-legislation endpoints.
+type ApiBill struct {
+	Congress       int    `json:"congress"`
+	Type           string `json:"type"`
+	Number         string `json:"number"`
+	Title          string `json:"title"`
+	IntroducedDate string `json:"introducedDate"`
+	LatestAction   struct {
+		ActionDate string `json:"actionDate"`
+		Text       string `json:"text"`
+	} `json:"latestAction"`
+	URL string `json:"url"`
+}
+
+// apiBillToBill converts an ApiBill to a client-facing Bill. Dates that fail
+// to parse (the API always sends "YYYY-MM-DD", but we don't trust that blindly)
+// are left as the zero time.
+func apiBillToBill(apiB ApiBill) Bill {
+	introduced, _ := time.Parse("2006-01-02", apiB.IntroducedDate)
+	latestAction, _ := time.Parse("2006-01-02", apiB.LatestAction.ActionDate)
+
+	return Bill{
+		Congress:         apiB.Congress,
+		Type:             apiB.Type,
+		Number:           apiB.Number,
+		Title:            apiB.Title,
+		IntroducedDate:   introduced,
+		LatestActionDate: latestAction,
+		LatestActionText: apiB.LatestAction.Text,
+		URL:              apiB.URL,
+	}
 }
 
 // ApiMember is structured to match the member object from the Congress.gov API.
@@ -82,25 +134,6 @@ func apiMemberToMember(apiM ApiMember) (Member, bool) {
 		firstName = ""
 	}
 
-	var partyDisplay string
-	switch apiM.Party {
-	case "Democratic":
-		partyDisplay = " (D)"
-	case "Republican":
-		partyDisplay = " (R)"
-	case "Independent":
-		partyDisplay = " (I)"
-	case "Libertarian":
-		partyDisplay = " (L)"
-	case "Green":
-		partyDisplay = " (G)"
-	default:
-		// For other parties, just use the initial if available.
-		if len(apiM.Party) > 0 {
-			partyDisplay = fmt.Sprintf(" (%s)", apiM.Party)
-		}
-	}
-
 	var districtDisplay string
 
 	if apiM.District == 0 {
@@ -118,9 +151,33 @@ func apiMemberToMember(apiM ApiMember) (Member, bool) {
 		ID:         apiM.BioguideID,
 		FirstName:  firstName,
 		LastName:   lastName,
-		Party:      partyDisplay,
+		Party:      partyDisplay(apiM.Party),
 		District:   districtDisplay,
 		InitalYear: firstTerm.StartYear,
 		ImageURL:   imageURL,
 	}, true
 }
+
+// partyDisplay maps a party name as returned by an upstream API (Congress.gov's
+// "partyName", OpenStates' "party") to the short parenthesized form the Flutter
+// client expects, e.g. "Democratic" -> " (D)".
+func partyDisplay(party string) string {
+	switch party {
+	case "Democratic":
+		return " (D)"
+	case "Republican":
+		return " (R)"
+	case "Independent":
+		return " (I)"
+	case "Libertarian":
+		return " (L)"
+	case "Green":
+		return " (G)"
+	default:
+		// For other parties, just use the name in parens if available.
+		if len(party) > 0 {
+			return fmt.Sprintf(" (%s)", party)
+		}
+		return ""
+	}
+}
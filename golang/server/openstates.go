@@ -0,0 +1,199 @@
+package main
+
+// openstates.go
+// Implements MemberProvider against OpenStates (https://v3.openstates.org),
+// which covers state-level legislators that Congress.gov doesn't know about.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const openStatesBaseURL = "https://v3.openstates.org"
+
+// openStatesPerPage is the page size requested from OpenStates; 50 is the
+// API's own default.
+const openStatesPerPage = "50"
+
+// openStatesMaxPages bounds how many pages MembersByState will follow, a
+// sane cap (~500 legislators at 50/page) comfortably above even the largest
+// state legislatures (e.g. New Hampshire's 400+ member House).
+const openStatesMaxPages = 10
+
+// openStatesProvider implements MemberProvider against OpenStates.
+type openStatesProvider struct{}
+
+func (openStatesProvider) MembersByState(ctx context.Context, state string) ([]Member, error) {
+	var members []Member
+	for page := 1; page <= openStatesMaxPages; page++ {
+		rawJSON, err := fetchOpenStatesJSON(ctx, "/people", map[string]string{
+			"jurisdiction": state,
+			"per_page":     openStatesPerPage,
+			"page":         strconv.Itoa(page),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching openstates members: %w", err)
+		}
+
+		var parsed openStatesPeopleResponse
+		if err := json.Unmarshal(rawJSON, &parsed); err != nil {
+			return nil, fmt.Errorf("failed decoding openstates response: %w", err)
+		}
+
+		for _, p := range parsed.Results {
+			members = append(members, openStatesPersonToMember(p))
+		}
+
+		if page >= parsed.Pagination.MaxPage {
+			return members, nil
+		}
+	}
+
+	log.Printf("openstates members for %s hit the %d-page cap; results may be incomplete", state, openStatesMaxPages)
+	return members, nil
+}
+
+func (openStatesProvider) MembersByLocation(ctx context.Context, lat, lon float64) ([]Member, error) {
+	rawJSON, err := fetchOpenStatesJSON(ctx, "/people.geo", map[string]string{
+		"lat": fmt.Sprintf("%f", lat),
+		"lng": fmt.Sprintf("%f", lon),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching openstates members by location: %w", err)
+	}
+
+	var parsed openStatesPeopleResponse
+	if err := json.Unmarshal(rawJSON, &parsed); err != nil {
+		return nil, fmt.Errorf("failed decoding openstates geo response: %w", err)
+	}
+
+	members := make([]Member, 0, len(parsed.Results))
+	for _, p := range parsed.Results {
+		members = append(members, openStatesPersonToMember(p))
+	}
+	return members, nil
+}
+
+// openStatesPerson is structured to match the person object from the OpenStates
+// v3 "/people" endpoint.
+type openStatesPerson struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	GivenName   string `json:"given_name"`
+	FamilyName  string `json:"family_name"`
+	Party       string `json:"party"`
+	Image       string `json:"image"`
+	CurrentRole struct {
+		Title    string `json:"title"`
+		District string `json:"district"`
+	} `json:"current_role"`
+}
+
+// openStatesPeopleResponse is the top-level shape of a "/people" response.
+type openStatesPeopleResponse struct {
+	Results    []openStatesPerson `json:"results"`
+	Pagination struct {
+		MaxPage int `json:"max_page"`
+	} `json:"pagination"`
+}
+
+// openStatesPersonToMember converts an openStatesPerson to a client-facing Member.
+func openStatesPersonToMember(p openStatesPerson) Member {
+	return Member{
+		ID:        "os-" + p.ID,
+		FirstName: p.GivenName,
+		LastName:  p.FamilyName,
+		Party:     partyDisplay(p.Party),
+		District:  stateLegislatureDistrictDisplay(p.CurrentRole.Title, p.CurrentRole.District),
+		ImageURL:  p.Image,
+		Chamber:   "state-legislature",
+	}
+}
+
+// stateLegislatureDistrictDisplay formats a state legislature role into a
+// human-readable district string, e.g. "Senate District 12".
+func stateLegislatureDistrictDisplay(title, district string) string {
+	if district == "" {
+		return title
+	}
+	return fmt.Sprintf("%s District %s", title, district)
+}
+
+// readOpenStatesAPIKey retrieves the OpenStates API key from environment variables.
+func readOpenStatesAPIKey() (string, error) {
+	key := os.Getenv("OPENSTATES_API_KEY")
+	if key == "" {
+		return "", errors.New("OPENSTATES_API_KEY environment variable is not set; get a key at https://open.pluralpolicy.com")
+	}
+	return key, nil
+}
+
+// fetchOpenStatesJSON performs a GET request against the OpenStates v3 API,
+// authenticating via the X-API-KEY header, with the same simple retry
+// mechanism and ctx-bound deadline fetchJSON uses for Congress.gov.
+func fetchOpenStatesJSON(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+	apiKey, err := readOpenStatesAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(openStatesBaseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid openstates path: %w", err)
+	}
+	q := u.Query()
+	for k, v := range params {
+		q.Set(k, v)
+	}
+	u.RawQuery = q.Encode()
+
+	ctx, cancel := withAPIDeadline(ctx)
+	defer cancel()
+
+	client := &http.Client{}
+
+	var resp *http.Response
+	const maxRetries = 3
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if reqErr != nil {
+			return nil, fmt.Errorf("invalid openstates request: %w", reqErr)
+		}
+		req.Header.Set("X-API-KEY", apiKey)
+
+		resp, err = client.Do(req)
+		if err == nil {
+			break // Success
+		}
+		if attempt == maxRetries {
+			return nil, fmt.Errorf("http request failed after %d attempts: %w", maxRetries, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, fmt.Errorf("openstates api returned non-200 status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading openstates response body: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+// providers.go
+// Defines MemberProvider, the abstraction between representativesHandler and
+// the various upstream data sources (mock data, Congress.gov, OpenStates), and
+// the MODE-driven dispatch that picks (or combines) them.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// locationCache holds merged MembersByLocation results, keyed by mode and a
+// rounded (lat, lon) grid cell. It gets a shorter TTL than the per-state
+// caches since a point lookup is more likely to be a one-off than a repeat
+// visitor checking their own state. It's bounded because the grid of
+// possible (lat, lon) keys is effectively unlimited.
+var locationCache = NewBoundedCache[[]Member](locationCacheMaxSize)
+
+const (
+	locationCacheTTL     = 10 * time.Minute
+	locationCacheMaxSize = 10_000
+)
+
+// MemberProvider is implemented by each backend capable of answering
+// "who represents this state / this point".
+type MemberProvider interface {
+	// MembersByState returns the legislators representing a state, given its
+	// two-letter postal code (e.g. "NY"). It returns promptly if ctx is
+	// canceled or its deadline passes.
+	MembersByState(ctx context.Context, state string) ([]Member, error)
+	// MembersByLocation returns the legislators whose district contains the
+	// given latitude/longitude. It returns promptly if ctx is canceled or its
+	// deadline passes.
+	MembersByLocation(ctx context.Context, lat, lon float64) ([]Member, error)
+}
+
+// providersForMode resolves the MemberProvider(s) to use for a given MODE value.
+// Recognized values are "mock" (the default), "congress", "openstates", and
+// "all" (which queries congress and openstates concurrently).
+func providersForMode(mode string) ([]MemberProvider, error) {
+	switch strings.ToLower(mode) {
+	case "", "mock":
+		return []MemberProvider{mockProvider{}}, nil
+	case "congress":
+		return []MemberProvider{congressProvider{}}, nil
+	case "openstates":
+		return []MemberProvider{openStatesProvider{}}, nil
+	case "all":
+		return []MemberProvider{congressProvider{}, openStatesProvider{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown MODE %q", mode)
+	}
+}
+
+// membersByStateFromProviders queries every given provider for a state and merges
+// the results. A single provider is called directly; multiple providers (MODE=all)
+// are queried concurrently via errgroup so a slow one doesn't block the others.
+func membersByStateFromProviders(ctx context.Context, providers []MemberProvider, state string) ([]Member, error) {
+	return fanOutMembers(ctx, providers, func(ctx context.Context, p MemberProvider) ([]Member, error) {
+		return p.MembersByState(ctx, state)
+	})
+}
+
+// membersByLocationFromMode resolves the provider(s) for mode and returns the
+// legislators whose district contains (lat, lon), caching the merged result.
+func membersByLocationFromMode(ctx context.Context, mode string, lat, lon float64) ([]Member, error) {
+	providers, err := providersForMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	key := locationCacheKey(mode, lat, lon)
+	return locationCache.GetOrLoad(key, locationCacheTTL, func() ([]Member, error) {
+		return fanOutMembers(ctx, providers, func(ctx context.Context, p MemberProvider) ([]Member, error) {
+			return p.MembersByLocation(ctx, lat, lon)
+		})
+	})
+}
+
+// locationCacheKey rounds (lat, lon) to a 3-decimal-degree grid (~111m), which
+// is precise enough to distinguish districts while letting nearby requests
+// share a cache entry.
+func locationCacheKey(mode string, lat, lon float64) string {
+	return fmt.Sprintf("%s|%.3f,%.3f", mode, lat, lon)
+}
+
+// fanOutMembers calls fetch for each provider, running them concurrently via
+// errgroup when there's more than one, and merges the resulting member lists.
+// A provider that errors (e.g. OpenStates failing because its API key isn't
+// provisioned) is logged and excluded from the merge rather than failing the
+// whole request — callers care about "who represents this area" and a
+// partial answer from the remaining providers beats a 500. Only when every
+// provider errors do we return an error ourselves.
+func fanOutMembers(ctx context.Context, providers []MemberProvider, fetch func(context.Context, MemberProvider) ([]Member, error)) ([]Member, error) {
+	if len(providers) == 1 {
+		return fetch(ctx, providers[0])
+	}
+
+	results := make([][]Member, len(providers))
+	errs := make([]error, len(providers))
+	g, groupCtx := errgroup.WithContext(ctx)
+	for i, p := range providers {
+		i, p := i, p
+		g.Go(func() error {
+			members, err := fetch(groupCtx, p)
+			if err != nil {
+				errs[i] = err
+				return nil
+			}
+			results[i] = members
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var merged []Member
+	var failures int
+	for i, r := range results {
+		if errs[i] != nil {
+			log.Printf("provider %T failed, returning partial results: %v", providers[i], errs[i])
+			failures++
+			continue
+		}
+		merged = append(merged, r...)
+	}
+	if failures == len(providers) {
+		return nil, fmt.Errorf("all providers failed: %w", errs[0])
+	}
+	return merged, nil
+}